@@ -0,0 +1,249 @@
+package aws
+
+import (
+	"fmt"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/ssm"
+	"github.com/hashicorp/terraform/helper/schema"
+)
+
+func dataSourceAwsSsmAssociation() *schema.Resource {
+	return &schema.Resource{
+		Read: dataSourceAwsSsmAssociationRead,
+
+		Schema: map[string]*schema.Schema{
+			"association_id": {
+				Type:     schema.TypeString,
+				Optional: true,
+				Computed: true,
+			},
+			"association_name": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"instance_id": {
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+			"document_version": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"name": {
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+			"parameters": {
+				Type:     schema.TypeMap,
+				Computed: true,
+			},
+			"schedule_expression": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"max_concurrency": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"max_errors": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"compliance_severity": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"automation_target_parameter_name": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"apply_only_at_cron_interval": {
+				Type:     schema.TypeBool,
+				Computed: true,
+			},
+			"last_execution_date": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"overview": {
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"status": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"detailed_status": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+					},
+				},
+			},
+			"output_location": {
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"s3_bucket_name": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"s3_key_prefix": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+					},
+				},
+			},
+			"targets": {
+				Type:     schema.TypeList,
+				Optional: true,
+				Computed: true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"key": {
+							Type:     schema.TypeString,
+							Required: true,
+						},
+						"values": {
+							Type:     schema.TypeList,
+							Required: true,
+							Elem:     &schema.Schema{Type: schema.TypeString},
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func dataSourceAwsSsmAssociationRead(d *schema.ResourceData, meta interface{}) error {
+	ssmconn := meta.(*AWSClient).ssmconn
+
+	association, err := findSsmAssociation(ssmconn, d)
+	if err != nil {
+		return err
+	}
+
+	d.SetId(aws.StringValue(association.AssociationId))
+	d.Set("association_id", association.AssociationId)
+	d.Set("association_name", association.AssociationName)
+	d.Set("instance_id", association.InstanceId)
+	d.Set("name", association.Name)
+	d.Set("parameters", association.Parameters)
+	d.Set("schedule_expression", association.ScheduleExpression)
+	d.Set("document_version", association.DocumentVersion)
+	d.Set("max_concurrency", association.MaxConcurrency)
+	d.Set("max_errors", association.MaxErrors)
+	d.Set("compliance_severity", association.ComplianceSeverity)
+	d.Set("automation_target_parameter_name", association.AutomationTargetParameterName)
+	d.Set("apply_only_at_cron_interval", association.ApplyOnlyAtCronInterval)
+
+	if association.LastExecutionDate != nil {
+		d.Set("last_execution_date", association.LastExecutionDate.Format("2006-01-02T15:04:05Z07:00"))
+	}
+
+	if err := d.Set("overview", flattenAwsSsmAssociationOverview(association.Overview)); err != nil {
+		return fmt.Errorf("[DEBUG] Error setting overview error: %#v", err)
+	}
+
+	if err := d.Set("targets", flattenAwsSsmTargets(association.Targets)); err != nil {
+		return fmt.Errorf("[DEBUG] Error setting targets error: %#v", err)
+	}
+
+	if err := d.Set("output_location", flattenAwsSsmAssociationOutoutLocation(association.OutputLocation)); err != nil {
+		return fmt.Errorf("[DEBUG] Error setting output_location error: %#v", err)
+	}
+
+	return nil
+}
+
+// findSsmAssociation resolves the data source's lookup arguments to a single
+// AssociationDescription, either directly via association_id or by listing
+// associations for (name, instance_id)/(name, targets) and matching the one
+// result. SSM auto-creates default associations for some documents (e.g.
+// inspector and patch baseline documents), so this lets users adopt those
+// without a direct association_id to import.
+func findSsmAssociation(conn *ssm.SSM, d *schema.ResourceData) (*ssm.AssociationDescription, error) {
+	if v, ok := d.GetOk("association_id"); ok {
+		resp, err := conn.DescribeAssociation(&ssm.DescribeAssociationInput{
+			AssociationId: aws.String(v.(string)),
+		})
+		if err != nil {
+			return nil, fmt.Errorf("[ERROR] Error describing SSM association %q: %s", v.(string), err)
+		}
+		if resp.AssociationDescription == nil {
+			return nil, fmt.Errorf("[ERROR] No SSM association found with id %q", v.(string))
+		}
+		return resp.AssociationDescription, nil
+	}
+
+	name, ok := d.GetOk("name")
+	if !ok {
+		return nil, fmt.Errorf("[ERROR] One of association_id or name must be set")
+	}
+
+	filters := []*ssm.AssociationFilter{
+		{
+			Key:   aws.String(ssm.AssociationFilterKeyName),
+			Value: aws.String(name.(string)),
+		},
+	}
+
+	if v, ok := d.GetOk("instance_id"); ok {
+		filters = append(filters, &ssm.AssociationFilter{
+			Key:   aws.String(ssm.AssociationFilterKeyInstanceId),
+			Value: aws.String(v.(string)),
+		})
+	}
+
+	var associations []*ssm.Association
+	input := &ssm.ListAssociationsInput{
+		AssociationFilterList: filters,
+	}
+
+	for {
+		resp, err := conn.ListAssociations(input)
+		if err != nil {
+			return nil, fmt.Errorf("[ERROR] Error listing SSM associations for document %q: %s", name.(string), err)
+		}
+
+		associations = append(associations, resp.Associations...)
+
+		if resp.NextToken == nil {
+			break
+		}
+		input.NextToken = resp.NextToken
+	}
+
+	if v, ok := d.GetOk("targets"); ok {
+		wantTargets := expandAwsSsmTargets(v.([]interface{}))
+		var matched []*ssm.Association
+		for _, a := range associations {
+			if ssmAssociationTargetsEqual(a.Targets, wantTargets) {
+				matched = append(matched, a)
+			}
+		}
+		associations = matched
+	}
+
+	if len(associations) == 0 {
+		return nil, fmt.Errorf("[ERROR] No SSM association found for document %q", name.(string))
+	}
+
+	if len(associations) > 1 {
+		return nil, fmt.Errorf("[ERROR] Multiple SSM associations found for document %q; narrow the search with instance_id or targets", name.(string))
+	}
+
+	describeResp, err := conn.DescribeAssociation(&ssm.DescribeAssociationInput{
+		AssociationId: associations[0].AssociationId,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("[ERROR] Error describing SSM association %q: %s", aws.StringValue(associations[0].AssociationId), err)
+	}
+
+	return describeResp.AssociationDescription, nil
+}
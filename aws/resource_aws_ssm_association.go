@@ -3,18 +3,31 @@ package aws
 import (
 	"fmt"
 	"log"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
 
 	"github.com/aws/aws-sdk-go/aws"
 	"github.com/aws/aws-sdk-go/service/ssm"
+	"github.com/hashicorp/terraform/helper/resource"
 	"github.com/hashicorp/terraform/helper/schema"
+	"github.com/hashicorp/terraform/helper/validation"
 )
 
+// ssmAssociationTargetsMaxItems is the current service-side maximum number of
+// targets (of any supported key type) an association can be scoped to.
+const ssmAssociationTargetsMaxItems = 100
+
 func resourceAwsSsmAssociation() *schema.Resource {
 	return &schema.Resource{
 		Create: resourceAwsSsmAssociationCreate,
 		Read:   resourceAwsSsmAssociationRead,
 		Update: resourceAwsSsmAssociationUpdate,
 		Delete: resourceAwsSsmAssociationDelete,
+		Importer: &schema.ResourceImporter{
+			State: schema.ImportStatePassthrough,
+		},
 
 		MigrateState:  resourceAwsSsmAssociationMigrateState,
 		SchemaVersion: 1,
@@ -52,6 +65,61 @@ func resourceAwsSsmAssociation() *schema.Resource {
 				Type:     schema.TypeString,
 				Optional: true,
 			},
+			"max_concurrency": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				ValidateFunc: validateAwsSSMAssociationRateControl,
+			},
+			"max_errors": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				ValidateFunc: validateAwsSSMAssociationRateControl,
+			},
+			"compliance_severity": {
+				Type:     schema.TypeString,
+				Optional: true,
+				ValidateFunc: validation.StringInSlice([]string{
+					ssm.AssociationComplianceSeverityCritical,
+					ssm.AssociationComplianceSeverityHigh,
+					ssm.AssociationComplianceSeverityMedium,
+					ssm.AssociationComplianceSeverityLow,
+					ssm.AssociationComplianceSeverityUnspecified,
+				}, false),
+			},
+			"automation_target_parameter_name": {
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+			"apply_only_at_cron_interval": {
+				Type:     schema.TypeBool,
+				Optional: true,
+				Default:  false,
+			},
+			"wait_for_success_timeout_seconds": {
+				Type:     schema.TypeInt,
+				Optional: true,
+				Default:  0,
+			},
+			"last_execution_date": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"overview": {
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"status": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"detailed_status": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+					},
+				},
+			},
 			"output_location": {
 				Type:     schema.TypeList,
 				MaxItems: 1,
@@ -70,15 +138,17 @@ func resourceAwsSsmAssociation() *schema.Resource {
 				},
 			},
 			"targets": {
-				Type:     schema.TypeList,
-				Optional: true,
-				Computed: true,
-				MaxItems: 5,
+				Type:          schema.TypeList,
+				Optional:      true,
+				Computed:      true,
+				MaxItems:      ssmAssociationTargetsMaxItems,
+				ConflictsWith: []string{"targets_from"},
 				Elem: &schema.Resource{
 					Schema: map[string]*schema.Schema{
 						"key": {
-							Type:     schema.TypeString,
-							Required: true,
+							Type:         schema.TypeString,
+							Required:     true,
+							ValidateFunc: validateAwsSsmTargetKey,
 						},
 						"values": {
 							Type:     schema.TypeList,
@@ -88,6 +158,21 @@ func resourceAwsSsmAssociation() *schema.Resource {
 					},
 				},
 			},
+			"targets_from": {
+				Type:          schema.TypeList,
+				Optional:      true,
+				MaxItems:      1,
+				ConflictsWith: []string{"targets"},
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"resource_group_arn": {
+							Type:         schema.TypeString,
+							Required:     true,
+							ValidateFunc: validateArn,
+						},
+					},
+				},
+			},
 		},
 	}
 }
@@ -117,11 +202,43 @@ func resourceAwsSsmAssociationCreate(d *schema.ResourceData, meta interface{}) e
 		associationInput.ScheduleExpression = aws.String(v.(string))
 	}
 
-	if v, ok := d.GetOk("parameters"); ok {
-		associationInput.Parameters = expandSSMDocumentParameters(v.(map[string]interface{}))
+	if v, ok := d.GetOk("max_concurrency"); ok {
+		associationInput.MaxConcurrency = aws.String(v.(string))
+	}
+
+	if v, ok := d.GetOk("max_errors"); ok {
+		associationInput.MaxErrors = aws.String(v.(string))
+	}
+
+	if v, ok := d.GetOk("compliance_severity"); ok {
+		associationInput.ComplianceSeverity = aws.String(v.(string))
+	}
+
+	if v, ok := d.GetOk("automation_target_parameter_name"); ok {
+		associationInput.AutomationTargetParameterName = aws.String(v.(string))
+	}
+
+	if v, ok := d.GetOkExists("apply_only_at_cron_interval"); ok {
+		associationInput.ApplyOnlyAtCronInterval = aws.Bool(v.(bool))
+	}
+
+	docParams, err := ssmDocumentParameterTypes(ssmconn, d.Get("name").(string), d.Get("document_version").(string))
+	if err != nil {
+		return err
+	}
+
+	params := d.Get("parameters").(map[string]interface{})
+	if err := validateSsmAssociationParameters(params, docParams, d.Get("automation_target_parameter_name").(string)); err != nil {
+		return fmt.Errorf("[ERROR] Error validating parameters against SSM document %q: %s", d.Get("name").(string), err)
 	}
 
-	if _, ok := d.GetOk("targets"); ok {
+	if len(params) > 0 {
+		associationInput.Parameters = expandSSMDocumentParameters(params, docParams)
+	}
+
+	if v, ok := d.GetOk("targets_from"); ok {
+		associationInput.Targets = expandAwsSsmTargetsFromResourceGroup(v.([]interface{}))
+	} else if _, ok := d.GetOk("targets"); ok {
 		associationInput.Targets = expandAwsSsmTargets(d.Get("targets").([]interface{}))
 	}
 
@@ -141,6 +258,12 @@ func resourceAwsSsmAssociationCreate(d *schema.ResourceData, meta interface{}) e
 	d.SetId(*resp.AssociationDescription.AssociationId)
 	d.Set("association_id", resp.AssociationDescription.AssociationId)
 
+	if timeout := d.Get("wait_for_success_timeout_seconds").(int); timeout > 0 {
+		if err := waitForSsmAssociationSuccess(ssmconn, d.Id(), "", time.Duration(timeout)*time.Second); err != nil {
+			return err
+		}
+	}
+
 	return resourceAwsSsmAssociationRead(d, meta)
 }
 
@@ -174,6 +297,25 @@ func resourceAwsSsmAssociationRead(d *schema.ResourceData, meta interface{}) err
 	d.Set("association_id", association.AssociationId)
 	d.Set("schedule_expression", association.ScheduleExpression)
 	d.Set("document_version", association.DocumentVersion)
+	d.Set("max_concurrency", association.MaxConcurrency)
+	d.Set("max_errors", association.MaxErrors)
+	d.Set("compliance_severity", association.ComplianceSeverity)
+	d.Set("automation_target_parameter_name", association.AutomationTargetParameterName)
+	d.Set("apply_only_at_cron_interval", association.ApplyOnlyAtCronInterval)
+
+	// wait_for_success_timeout_seconds has no API counterpart; give an imported
+	// association the schema default so its state matches a fresh apply.
+	if _, ok := d.GetOkExists("wait_for_success_timeout_seconds"); !ok {
+		d.Set("wait_for_success_timeout_seconds", 0)
+	}
+
+	if association.LastExecutionDate != nil {
+		d.Set("last_execution_date", association.LastExecutionDate.Format(time.RFC3339))
+	}
+
+	if err := d.Set("overview", flattenAwsSsmAssociationOverview(association.Overview)); err != nil {
+		return fmt.Errorf("[DEBUG] Error setting overview error: %#v", err)
+	}
 
 	if err := d.Set("targets", flattenAwsSsmTargets(association.Targets)); err != nil {
 		return fmt.Errorf("[DEBUG] Error setting targets error: %#v", err)
@@ -195,6 +337,16 @@ func resourceAwsSsmAssociationUpdate(d *schema.ResourceData, meta interface{}) e
 		AssociationId: aws.String(d.Get("association_id").(string)),
 	}
 
+	docParams, err := ssmDocumentParameterTypes(ssmconn, d.Get("name").(string), d.Get("document_version").(string))
+	if err != nil {
+		return err
+	}
+
+	params := d.Get("parameters").(map[string]interface{})
+	if err := validateSsmAssociationParameters(params, docParams, d.Get("automation_target_parameter_name").(string)); err != nil {
+		return fmt.Errorf("[ERROR] Error validating parameters against SSM document %q: %s", d.Get("name").(string), err)
+	}
+
 	// AWS creates a new version every time the association is updated, so everything should be passed in the update.
 
 	hasChanges := false
@@ -211,6 +363,26 @@ func resourceAwsSsmAssociationUpdate(d *schema.ResourceData, meta interface{}) e
 		hasChanges = true
 	}
 
+	if d.HasChange("max_concurrency") {
+		hasChanges = true
+	}
+
+	if d.HasChange("max_errors") {
+		hasChanges = true
+	}
+
+	if d.HasChange("compliance_severity") {
+		hasChanges = true
+	}
+
+	if d.HasChange("automation_target_parameter_name") {
+		hasChanges = true
+	}
+
+	if d.HasChange("apply_only_at_cron_interval") {
+		hasChanges = true
+	}
+
 	if d.HasChange("parameters") {
 		hasChanges = true
 	}
@@ -219,7 +391,7 @@ func resourceAwsSsmAssociationUpdate(d *schema.ResourceData, meta interface{}) e
 		hasChanges = true
 	}
 
-	if d.HasChange("targets") {
+	if d.HasChange("targets") || d.HasChange("targets_from") {
 		hasChanges = true
 	}
 
@@ -236,11 +408,33 @@ func resourceAwsSsmAssociationUpdate(d *schema.ResourceData, meta interface{}) e
 			associationInput.ScheduleExpression = aws.String(v.(string))
 		}
 
-		if v, ok := d.GetOk("parameters"); ok {
-			associationInput.Parameters = expandSSMDocumentParameters(v.(map[string]interface{}))
+		if v, ok := d.GetOk("max_concurrency"); ok {
+			associationInput.MaxConcurrency = aws.String(v.(string))
+		}
+
+		if v, ok := d.GetOk("max_errors"); ok {
+			associationInput.MaxErrors = aws.String(v.(string))
+		}
+
+		if v, ok := d.GetOk("compliance_severity"); ok {
+			associationInput.ComplianceSeverity = aws.String(v.(string))
 		}
 
-		if _, ok := d.GetOk("targets"); ok {
+		if v, ok := d.GetOk("automation_target_parameter_name"); ok {
+			associationInput.AutomationTargetParameterName = aws.String(v.(string))
+		}
+
+		if v, ok := d.GetOkExists("apply_only_at_cron_interval"); ok {
+			associationInput.ApplyOnlyAtCronInterval = aws.Bool(v.(bool))
+		}
+
+		if len(params) > 0 {
+			associationInput.Parameters = expandSSMDocumentParameters(params, docParams)
+		}
+
+		if v, ok := d.GetOk("targets_from"); ok {
+			associationInput.Targets = expandAwsSsmTargetsFromResourceGroup(v.([]interface{}))
+		} else if _, ok := d.GetOk("targets"); ok {
 			associationInput.Targets = expandAwsSsmTargets(d.Get("targets").([]interface{}))
 		}
 
@@ -249,11 +443,28 @@ func resourceAwsSsmAssociationUpdate(d *schema.ResourceData, meta interface{}) e
 		}
 	}
 
-	_, err := ssmconn.UpdateAssociation(associationInput)
+	timeout := d.Get("wait_for_success_timeout_seconds").(int)
+
+	var priorExecutionId string
+	if timeout > 0 {
+		var err error
+		priorExecutionId, err = lastSsmAssociationExecutionId(ssmconn, d.Id())
+		if err != nil {
+			return err
+		}
+	}
+
+	_, err = ssmconn.UpdateAssociation(associationInput)
 	if err != nil {
 		return fmt.Errorf("[ERROR] Error updating SSM association: %s", err)
 	}
 
+	if timeout > 0 {
+		if err := waitForSsmAssociationSuccess(ssmconn, d.Id(), priorExecutionId, time.Duration(timeout)*time.Second); err != nil {
+			return err
+		}
+	}
+
 	return resourceAwsSsmAssociationRead(d, meta)
 }
 
@@ -275,15 +486,169 @@ func resourceAwsSsmAssociationDelete(d *schema.ResourceData, meta interface{}) e
 	return nil
 }
 
-func expandSSMDocumentParameters(params map[string]interface{}) map[string][]*string {
-	var docParams = make(map[string][]*string)
+// lastSsmAssociationExecutionId returns the execution id of the association's
+// most recent execution, if any, so callers can tell it apart from the
+// execution triggered by a subsequent Create/Update.
+func lastSsmAssociationExecutionId(conn *ssm.SSM, id string) (string, error) {
+	execOut, err := conn.DescribeAssociationExecutions(&ssm.DescribeAssociationExecutionsInput{
+		AssociationId: aws.String(id),
+		MaxResults:    aws.Int64(1),
+	})
+	if err != nil {
+		return "", fmt.Errorf("[ERROR] Error describing SSM association executions: %s", err)
+	}
+
+	if len(execOut.AssociationExecutions) == 0 {
+		return "", nil
+	}
+
+	return aws.StringValue(execOut.AssociationExecutions[0].ExecutionId), nil
+}
+
+// waitForSsmAssociationSuccess polls DescribeAssociationExecutions until a
+// new execution of association id (one other than priorExecutionId) reaches
+// a terminal Success status, surfacing the aggregated per-target failures if
+// it fails instead. Mirrors the invocation-polling pattern used by
+// aws_ssm_maintenance_window_task.
+func waitForSsmAssociationSuccess(conn *ssm.SSM, id string, priorExecutionId string, timeout time.Duration) error {
+	return resource.Retry(timeout, func() *resource.RetryError {
+		execOut, err := conn.DescribeAssociationExecutions(&ssm.DescribeAssociationExecutionsInput{
+			AssociationId: aws.String(id),
+			MaxResults:    aws.Int64(1),
+		})
+		if err != nil {
+			return resource.NonRetryableError(fmt.Errorf("[ERROR] Error describing SSM association executions: %s", err))
+		}
+
+		if len(execOut.AssociationExecutions) == 0 {
+			return resource.RetryableError(fmt.Errorf("SSM association %s has not executed yet", id))
+		}
+
+		latest := execOut.AssociationExecutions[0]
+
+		if aws.StringValue(latest.ExecutionId) == priorExecutionId {
+			return resource.RetryableError(fmt.Errorf("SSM association %s has not started a new execution yet", id))
+		}
+
+		switch aws.StringValue(latest.Status) {
+		case ssm.AssociationStatusNameSuccess:
+			return nil
+		case ssm.AssociationStatusNameFailed:
+			targetsOut, err := conn.DescribeAssociationExecutionTargets(&ssm.DescribeAssociationExecutionTargetsInput{
+				AssociationId: aws.String(id),
+				ExecutionId:   latest.ExecutionId,
+			})
+			if err != nil {
+				return resource.NonRetryableError(fmt.Errorf("[ERROR] Error describing SSM association execution targets: %s", err))
+			}
+
+			var failures []string
+			for _, target := range targetsOut.AssociationExecutionTargets {
+				if aws.StringValue(target.Status) == ssm.AssociationStatusNameFailed {
+					failures = append(failures, fmt.Sprintf("%s: %s", aws.StringValue(target.ResourceId), aws.StringValue(target.DetailedStatus)))
+				}
+			}
+
+			return resource.NonRetryableError(fmt.Errorf("[ERROR] SSM association %s execution %s failed:\n%s", id, aws.StringValue(latest.ExecutionId), strings.Join(failures, "\n")))
+		default:
+			return resource.RetryableError(fmt.Errorf("SSM association %s execution status is %s", id, aws.StringValue(latest.Status)))
+		}
+	})
+}
+
+// ssmDocumentParameterTypes looks up the Parameters declared by the SSM
+// document backing an association, keyed by parameter name, so callers can
+// validate supplied parameters and expand StringList values correctly.
+func ssmDocumentParameterTypes(conn *ssm.SSM, name string, documentVersion string) (map[string]*ssm.DocumentParameter, error) {
+	input := &ssm.DescribeDocumentInput{
+		Name: aws.String(name),
+	}
+
+	if documentVersion != "" {
+		input.DocumentVersion = aws.String(documentVersion)
+	}
+
+	out, err := conn.DescribeDocument(input)
+	if err != nil {
+		return nil, fmt.Errorf("[ERROR] Error describing SSM document %q: %s", name, err)
+	}
+
+	docParams := make(map[string]*ssm.DocumentParameter)
+	if out.Document == nil {
+		return docParams, nil
+	}
+
+	for _, p := range out.Document.Parameters {
+		if p.Name != nil {
+			docParams[*p.Name] = p
+		}
+	}
+
+	return docParams, nil
+}
+
+// validateSsmAssociationParameters rejects params that the document doesn't
+// declare, params missing for required (no DefaultValue) document
+// parameters, and values that don't match the document's declared type.
+// automationTargetParameterName is exempted from the required-parameter
+// check since SSM populates it per-target at execution time rather than
+// from the parameters map.
+func validateSsmAssociationParameters(params map[string]interface{}, docParams map[string]*ssm.DocumentParameter, automationTargetParameterName string) error {
+	for name := range params {
+		if _, ok := docParams[name]; !ok {
+			return fmt.Errorf("unknown parameter %q is not defined by the document", name)
+		}
+	}
+
+	for name, docParam := range docParams {
+		if name == automationTargetParameterName {
+			continue
+		}
+		if docParam.DefaultValue == nil {
+			if _, ok := params[name]; !ok {
+				return fmt.Errorf("required parameter %q is not set", name)
+			}
+		}
+	}
+
+	for name, v := range params {
+		docParam := docParams[name]
+		raw := v.(string)
+
+		switch aws.StringValue(docParam.Type) {
+		case "Boolean":
+			if raw != "true" && raw != "false" {
+				return fmt.Errorf("parameter %q must be \"true\" or \"false\", got %q", name, raw)
+			}
+		case "Integer":
+			if _, err := strconv.Atoi(raw); err != nil {
+				return fmt.Errorf("parameter %q must be an integer, got %q", name, raw)
+			}
+		}
+	}
+
+	return nil
+}
+
+func expandSSMDocumentParameters(params map[string]interface{}, docParams map[string]*ssm.DocumentParameter) map[string][]*string {
+	var docParamValues = make(map[string][]*string)
 	for k, v := range params {
-		values := make([]*string, 1)
-		values[0] = aws.String(v.(string))
-		docParams[k] = values
+		raw := v.(string)
+
+		if docParam, ok := docParams[k]; ok && aws.StringValue(docParam.Type) == "StringList" {
+			parts := strings.Split(raw, ",")
+			values := make([]*string, len(parts))
+			for i, part := range parts {
+				values[i] = aws.String(strings.TrimSpace(part))
+			}
+			docParamValues[k] = values
+			continue
+		}
+
+		docParamValues[k] = []*string{aws.String(raw)}
 	}
 
-	return docParams
+	return docParamValues
 }
 
 func expandSSMAssociationOutputLocation(config []interface{}) *ssm.InstanceAssociationOutputLocation {
@@ -325,3 +690,109 @@ func flattenAwsSsmAssociationOutoutLocation(location *ssm.InstanceAssociationOut
 
 	return result
 }
+
+func flattenAwsSsmAssociationOverview(overview *ssm.AssociationOverview) []map[string]interface{} {
+	if overview == nil {
+		return nil
+	}
+
+	return []map[string]interface{}{
+		{
+			"status":          aws.StringValue(overview.Status),
+			"detailed_status": aws.StringValue(overview.DetailedStatus),
+		},
+	}
+}
+
+// expandAwsSsmTargetsFromResourceGroup converts a targets_from block
+// referencing an aws_resourcegroups_group ARN into the resource-groups:Name
+// Target shape the API expects, so associations can scope to dynamic fleet
+// membership without enumerating individual targets in config.
+func expandAwsSsmTargetsFromResourceGroup(config []interface{}) []*ssm.Target {
+	if len(config) == 0 || config[0] == nil {
+		return nil
+	}
+
+	item := config[0].(map[string]interface{})
+	arn := item["resource_group_arn"].(string)
+
+	name := arn
+	if idx := strings.LastIndex(arn, "/"); idx != -1 {
+		name = arn[idx+1:]
+	}
+
+	return []*ssm.Target{
+		{
+			Key:    aws.String("resource-groups:Name"),
+			Values: []*string{aws.String(name)},
+		},
+	}
+}
+
+// validateAwsSsmTargetKey only constrains the new resource-groups:* key
+// prefixes; any other key form is left unvalidated since the service-side
+// enum of classic target keys (tag:<name>, InstanceIds, ResourceGroup, ...)
+// isn't exposed to the provider and existing configs may rely on forms this
+// code doesn't know about.
+func validateAwsSsmTargetKey(v interface{}, k string) (ws []string, errors []error) {
+	value := v.(string)
+	if strings.HasPrefix(value, "resource-groups:") {
+		if value != "resource-groups:Name" && value != "resource-groups:ResourceTypeFilters" {
+			errors = append(errors, fmt.Errorf("%q must be resource-groups:Name or resource-groups:ResourceTypeFilters when using the resource-groups: prefix, got: %s", k, value))
+		}
+	}
+	return
+}
+
+// ssmAssociationTargetsEqual reports whether two Target sets contain the same
+// key/values pairs, ignoring order, so the data source can match a desired
+// targets block against the targets of a listed association.
+func ssmAssociationTargetsEqual(a, b []*ssm.Target) bool {
+	if len(a) != len(b) {
+		return false
+	}
+
+	toSet := func(targets []*ssm.Target) map[string]map[string]bool {
+		set := make(map[string]map[string]bool, len(targets))
+		for _, t := range targets {
+			key := aws.StringValue(t.Key)
+			if set[key] == nil {
+				set[key] = make(map[string]bool)
+			}
+			for _, v := range t.Values {
+				set[key][aws.StringValue(v)] = true
+			}
+		}
+		return set
+	}
+
+	setA, setB := toSet(a), toSet(b)
+	if len(setA) != len(setB) {
+		return false
+	}
+
+	for key, values := range setA {
+		other, ok := setB[key]
+		if !ok || len(values) != len(other) {
+			return false
+		}
+		for v := range values {
+			if !other[v] {
+				return false
+			}
+		}
+	}
+
+	return true
+}
+
+// validateAwsSSMAssociationRateControl validates max_concurrency/max_errors
+// values, which the API accepts as either a plain count (e.g. "10") or a
+// percentage (e.g. "10%", up to "100%").
+func validateAwsSSMAssociationRateControl(v interface{}, k string) (ws []string, errors []error) {
+	value := v.(string)
+	if !regexp.MustCompile(`^([1-9][0-9]*|[0-9]{1,2}%|100%)$`).MatchString(value) {
+		errors = append(errors, fmt.Errorf("%q must be a positive integer (e.g. 10) or a percentage (e.g. 10%%), got: %s", k, value))
+	}
+	return
+}
@@ -0,0 +1,362 @@
+package aws
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/ssm"
+	"github.com/hashicorp/terraform/helper/acctest"
+	"github.com/hashicorp/terraform/helper/resource"
+	"github.com/hashicorp/terraform/terraform"
+)
+
+func TestExpandSSMDocumentParameters(t *testing.T) {
+	docParams := map[string]*ssm.DocumentParameter{
+		"InstanceIds": {Type: aws.String("StringList")},
+		"Comment":     {Type: aws.String("String")},
+	}
+
+	got := expandSSMDocumentParameters(map[string]interface{}{
+		"InstanceIds": "i-1, i-2,i-3",
+		"Comment":     "hello",
+	}, docParams)
+
+	if len(got["InstanceIds"]) != 3 {
+		t.Fatalf("expected 3 split StringList values, got %d", len(got["InstanceIds"]))
+	}
+	for i, want := range []string{"i-1", "i-2", "i-3"} {
+		if aws.StringValue(got["InstanceIds"][i]) != want {
+			t.Errorf("InstanceIds[%d] = %q, want %q", i, aws.StringValue(got["InstanceIds"][i]), want)
+		}
+	}
+
+	if len(got["Comment"]) != 1 || aws.StringValue(got["Comment"][0]) != "hello" {
+		t.Errorf("Comment = %v, want single-element [\"hello\"]", got["Comment"])
+	}
+}
+
+func TestValidateSsmAssociationParameters(t *testing.T) {
+	docParams := map[string]*ssm.DocumentParameter{
+		"InstanceId": {Type: aws.String("String")},
+		"Enabled":    {Type: aws.String("Boolean"), DefaultValue: aws.String("true")},
+		"Count":      {Type: aws.String("Integer"), DefaultValue: aws.String("1")},
+	}
+
+	cases := []struct {
+		name                          string
+		params                        map[string]interface{}
+		automationTargetParameterName string
+		wantErr                       bool
+	}{
+		{
+			name:    "missing required parameter",
+			params:  map[string]interface{}{},
+			wantErr: true,
+		},
+		{
+			name:                          "required parameter satisfied by automation_target_parameter_name",
+			params:                        map[string]interface{}{},
+			automationTargetParameterName: "InstanceId",
+			wantErr:                       false,
+		},
+		{
+			name:    "unknown parameter",
+			params:  map[string]interface{}{"InstanceId": "i-123", "Bogus": "x"},
+			wantErr: true,
+		},
+		{
+			name:    "bad boolean",
+			params:  map[string]interface{}{"InstanceId": "i-123", "Enabled": "yes"},
+			wantErr: true,
+		},
+		{
+			name:    "bad integer",
+			params:  map[string]interface{}{"InstanceId": "i-123", "Count": "one"},
+			wantErr: true,
+		},
+		{
+			name:    "valid",
+			params:  map[string]interface{}{"InstanceId": "i-123", "Enabled": "false", "Count": "3"},
+			wantErr: false,
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			err := validateSsmAssociationParameters(c.params, docParams, c.automationTargetParameterName)
+			if (err != nil) != c.wantErr {
+				t.Errorf("validateSsmAssociationParameters() error = %v, wantErr %v", err, c.wantErr)
+			}
+		})
+	}
+}
+
+func TestSsmAssociationTargetsEqual(t *testing.T) {
+	a := []*ssm.Target{
+		{Key: aws.String("tag:Name"), Values: []*string{aws.String("web"), aws.String("api")}},
+	}
+	b := []*ssm.Target{
+		{Key: aws.String("tag:Name"), Values: []*string{aws.String("api"), aws.String("web")}},
+	}
+	c := []*ssm.Target{
+		{Key: aws.String("tag:Name"), Values: []*string{aws.String("web")}},
+	}
+
+	if !ssmAssociationTargetsEqual(a, b) {
+		t.Error("expected targets with reordered values to be equal")
+	}
+	if ssmAssociationTargetsEqual(a, c) {
+		t.Error("expected targets with differing values to be unequal")
+	}
+	if ssmAssociationTargetsEqual(a, nil) {
+		t.Error("expected non-empty and nil targets to be unequal")
+	}
+}
+
+func TestValidateAwsSSMAssociationRateControl(t *testing.T) {
+	validValues := []string{"1", "10", "50%", "100%", "0%"}
+	for _, v := range validValues {
+		if _, errors := validateAwsSSMAssociationRateControl(v, "max_concurrency"); len(errors) != 0 {
+			t.Errorf("%q should be valid: %v", v, errors)
+		}
+	}
+
+	invalidValues := []string{"", "abc", "-1", "101%", "10 %"}
+	for _, v := range invalidValues {
+		if _, errors := validateAwsSSMAssociationRateControl(v, "max_concurrency"); len(errors) == 0 {
+			t.Errorf("%q should be invalid", v)
+		}
+	}
+}
+
+func TestValidateAwsSsmTargetKey(t *testing.T) {
+	if _, errors := validateAwsSsmTargetKey("resource-groups:Name", "key"); len(errors) != 0 {
+		t.Errorf("resource-groups:Name should be valid: %v", errors)
+	}
+	if _, errors := validateAwsSsmTargetKey("resource-groups:Bogus", "key"); len(errors) == 0 {
+		t.Error("resource-groups:Bogus should be invalid")
+	}
+	if _, errors := validateAwsSsmTargetKey("tag:AnythingGoesHere", "key"); len(errors) != 0 {
+		t.Errorf("pre-existing non-resource-groups key forms should remain unrestricted: %v", errors)
+	}
+}
+
+func TestAccAWSSSMAssociation_rateControlAndCompliance(t *testing.T) {
+	name := acctest.RandString(10)
+	resourceName := "aws_ssm_association.test"
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckAWSSSMAssociationDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccAWSSSMAssociationRateControlConfig(name),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckAWSSSMAssociationExists(resourceName),
+					resource.TestCheckResourceAttr(resourceName, "max_concurrency", "50%"),
+					resource.TestCheckResourceAttr(resourceName, "max_errors", "10%"),
+					resource.TestCheckResourceAttr(resourceName, "compliance_severity", "HIGH"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccAWSSSMAssociation_waitForSuccess(t *testing.T) {
+	name := acctest.RandString(10)
+	resourceName := "aws_ssm_association.test"
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckAWSSSMAssociationDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccAWSSSMAssociationWaitForSuccessConfig(name),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckAWSSSMAssociationExists(resourceName),
+					resource.TestCheckResourceAttr(resourceName, "overview.0.status", "Success"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccAWSSSMAssociation_import(t *testing.T) {
+	name := acctest.RandString(10)
+	resourceName := "aws_ssm_association.test"
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckAWSSSMAssociationDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccAWSSSMAssociationBasicConfig(name),
+				Check:  testAccCheckAWSSSMAssociationExists(resourceName),
+			},
+			{
+				ResourceName:      resourceName,
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
+
+func testAccCheckAWSSSMAssociationExists(n string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		rs, ok := s.RootModule().Resources[n]
+		if !ok {
+			return fmt.Errorf("Not found: %s", n)
+		}
+
+		if rs.Primary.ID == "" {
+			return fmt.Errorf("No SSM Association ID is set")
+		}
+
+		conn := testAccProvider.Meta().(*AWSClient).ssmconn
+		_, err := conn.DescribeAssociation(&ssm.DescribeAssociationInput{
+			AssociationId: aws.String(rs.Primary.ID),
+		})
+		return err
+	}
+}
+
+func testAccCheckAWSSSMAssociationDestroy(s *terraform.State) error {
+	conn := testAccProvider.Meta().(*AWSClient).ssmconn
+
+	for _, rs := range s.RootModule().Resources {
+		if rs.Type != "aws_ssm_association" {
+			continue
+		}
+
+		_, err := conn.DescribeAssociation(&ssm.DescribeAssociationInput{
+			AssociationId: aws.String(rs.Primary.ID),
+		})
+		if err != nil {
+			if isAWSErr(err, ssm.ErrCodeAssociationDoesNotExist, "") {
+				continue
+			}
+			return err
+		}
+
+		return fmt.Errorf("SSM Association still exists: %s", rs.Primary.ID)
+	}
+
+	return nil
+}
+
+func testAccAWSSSMAssociationBasicConfig(rName string) string {
+	return fmt.Sprintf(`
+resource "aws_ssm_document" "test" {
+  name          = "test_document_%s"
+  document_type = "Command"
+
+  content = <<DOC
+{
+  "schemaVersion": "1.2",
+  "description": "Check ip configuration of a Linux instance.",
+  "parameters": {},
+  "runtimeConfig": {
+    "aws:runShellScript": {
+      "properties": [
+        {
+          "id": "0.aws:runShellScript",
+          "runCommand": ["ifconfig"]
+        }
+      ]
+    }
+  }
+}
+DOC
+}
+
+resource "aws_ssm_association" "test" {
+  name = aws_ssm_document.test.name
+
+  targets {
+    key    = "tag:Name"
+    values = ["acceptance_test"]
+  }
+}
+`, rName)
+}
+
+func testAccAWSSSMAssociationRateControlConfig(rName string) string {
+	return fmt.Sprintf(`
+resource "aws_ssm_document" "test" {
+  name          = "test_document_%s"
+  document_type = "Command"
+
+  content = <<DOC
+{
+  "schemaVersion": "1.2",
+  "description": "Check ip configuration of a Linux instance.",
+  "parameters": {},
+  "runtimeConfig": {
+    "aws:runShellScript": {
+      "properties": [
+        {
+          "id": "0.aws:runShellScript",
+          "runCommand": ["ifconfig"]
+        }
+      ]
+    }
+  }
+}
+DOC
+}
+
+resource "aws_ssm_association" "test" {
+  name                = aws_ssm_document.test.name
+  max_concurrency     = "50%%"
+  max_errors          = "10%%"
+  compliance_severity = "HIGH"
+
+  targets {
+    key    = "tag:Name"
+    values = ["acceptance_test"]
+  }
+}
+`, rName)
+}
+
+func testAccAWSSSMAssociationWaitForSuccessConfig(rName string) string {
+	return fmt.Sprintf(`
+resource "aws_ssm_document" "test" {
+  name          = "test_document_%s"
+  document_type = "Command"
+
+  content = <<DOC
+{
+  "schemaVersion": "1.2",
+  "description": "Check ip configuration of a Linux instance.",
+  "parameters": {},
+  "runtimeConfig": {
+    "aws:runShellScript": {
+      "properties": [
+        {
+          "id": "0.aws:runShellScript",
+          "runCommand": ["ifconfig"]
+        }
+      ]
+    }
+  }
+}
+DOC
+}
+
+resource "aws_ssm_association" "test" {
+  name                              = aws_ssm_document.test.name
+  wait_for_success_timeout_seconds  = 600
+
+  targets {
+    key    = "tag:Name"
+    values = ["acceptance_test"]
+  }
+}
+`, rName)
+}